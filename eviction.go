@@ -0,0 +1,107 @@
+package mapcache
+
+// EvictionPolicy selects how a capacity-bounded MapCache (see WithSize)
+// chooses which entry to drop when a new key arrives at capacity.
+type EvictionPolicy int
+
+const (
+	// LRU evicts the least-recently-used entry. This is the default policy
+	// whenever WithSize is set without an explicit WithEvictionPolicy.
+	// Tracking recency requires a write lock on every Get hit (see touch),
+	// so reads are serialized the same as writes under this policy.
+	LRU EvictionPolicy = iota
+	// LFU evicts the least-frequently-used entry, breaking ties in favor of
+	// the least-recently-used of the tied entries. Like LRU, every hit takes
+	// a write lock to bump the key's frequency.
+	LFU
+	// FIFO evicts the entry that was inserted first, regardless of how
+	// often or recently it was read. Hits never reorder anything, so reads
+	// keep the usual RLock-only fast path.
+	FIFO
+)
+
+// WithEvictionPolicy selects the eviction policy used once a size-bounded
+// cache (see WithSize) is at capacity. It has no effect without WithSize.
+func WithEvictionPolicy(p EvictionPolicy) OptFunc {
+	return func(o *options) error {
+		o.EvictionPolicy = &p
+		return nil
+	}
+}
+
+// lfuEntry tracks the current frequency bucket node for a key under LFU.
+type lfuEntry[K comparable] struct {
+	node *listNode[K]
+	freq int
+}
+
+// lfuIndex implements O(1) LFU bookkeeping: each frequency has its own
+// evictList (ordered least- to most-recently touched within that
+// frequency), and minFreq tracks the bucket to evict from.
+type lfuIndex[K comparable] struct {
+	entries map[K]*lfuEntry[K]
+	buckets map[int]*evictList[K]
+	minFreq int
+}
+
+func newLFUIndex[K comparable]() *lfuIndex[K] {
+	return &lfuIndex[K]{
+		entries: make(map[K]*lfuEntry[K]),
+		buckets: make(map[int]*evictList[K]),
+	}
+}
+
+func (l *lfuIndex[K]) bucket(freq int) *evictList[K] {
+	b, ok := l.buckets[freq]
+	if !ok {
+		b = &evictList[K]{}
+		l.buckets[freq] = b
+	}
+	return b
+}
+
+// insert records a newly-inserted key at frequency 1.
+func (l *lfuIndex[K]) insert(key K) {
+	n := &listNode[K]{key: key}
+	l.entries[key] = &lfuEntry[K]{node: n, freq: 1}
+	l.bucket(1).pushFront(n)
+	l.minFreq = 1
+}
+
+// touch bumps key's frequency by one, for use on a cache hit.
+func (l *lfuIndex[K]) touch(key K) {
+	e, ok := l.entries[key]
+	if !ok {
+		return
+	}
+	old := l.bucket(e.freq)
+	old.remove(e.node)
+	if old.len == 0 && e.freq == l.minFreq {
+		l.minFreq++
+	}
+	e.freq++
+	e.node = &listNode[K]{key: key}
+	l.bucket(e.freq).pushFront(e.node)
+}
+
+// remove drops key from the index, e.g. on manual delete or TTL expiry.
+func (l *lfuIndex[K]) remove(key K) {
+	e, ok := l.entries[key]
+	if !ok {
+		return
+	}
+	l.bucket(e.freq).remove(e.node)
+	delete(l.entries, key)
+}
+
+// evict drops and returns the least-frequently-used key. ok is false if the
+// index is empty.
+func (l *lfuIndex[K]) evict() (key K, ok bool) {
+	b, exists := l.buckets[l.minFreq]
+	if !exists || b.len == 0 {
+		return key, false
+	}
+	n := b.popBack()
+	delete(l.entries, n.key)
+	return n.key, true
+}
@@ -0,0 +1,116 @@
+package mapcache_test
+
+import (
+	"testing"
+
+	mapcache "github.com/omniaura/mapcache"
+)
+
+func value(n int) func() (int, error) {
+	return func() (int, error) { return n, nil }
+}
+
+func TestMapCache_EvictionLRU(t *testing.T) {
+	mc, err := mapcache.New[string, int](mapcache.WithSize(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("b", value(2)); err != nil {
+		t.Fatal(err)
+	}
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("c", value(3)); err != nil {
+		t.Fatal(err)
+	}
+
+	present := map[string]bool{}
+	for k := range mc.All() {
+		present[k] = true
+	}
+	if present["b"] {
+		t.Errorf("expected \"b\" to be evicted as least-recently-used, got %v", present)
+	}
+	if !present["a"] || !present["c"] {
+		t.Errorf("expected \"a\" and \"c\" to remain, got %v", present)
+	}
+}
+
+func TestMapCache_EvictionFIFO(t *testing.T) {
+	mc, err := mapcache.New[string, int](
+		mapcache.WithSize(2),
+		mapcache.WithEvictionPolicy(mapcache.FIFO),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("b", value(2)); err != nil {
+		t.Fatal(err)
+	}
+	// Reading "a" again should not save it from FIFO eviction.
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("c", value(3)); err != nil {
+		t.Fatal(err)
+	}
+
+	present := map[string]bool{}
+	for k := range mc.All() {
+		present[k] = true
+	}
+	if present["a"] {
+		t.Errorf("expected \"a\" to be evicted as first-in, got %v", present)
+	}
+	if !present["b"] || !present["c"] {
+		t.Errorf("expected \"b\" and \"c\" to remain, got %v", present)
+	}
+}
+
+func TestMapCache_EvictionLFU(t *testing.T) {
+	mc, err := mapcache.New[string, int](
+		mapcache.WithSize(2),
+		mapcache.WithEvictionPolicy(mapcache.LFU),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("b", value(2)); err != nil {
+		t.Fatal(err)
+	}
+	// Read "a" twice more so "b" is the least-frequently-used entry.
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("c", value(3)); err != nil {
+		t.Fatal(err)
+	}
+
+	present := map[string]bool{}
+	for k := range mc.All() {
+		present[k] = true
+	}
+	if present["b"] {
+		t.Errorf("expected \"b\" to be evicted as least-frequently-used, got %v", present)
+	}
+	if !present["a"] || !present["c"] {
+		t.Errorf("expected \"a\" and \"c\" to remain, got %v", present)
+	}
+}
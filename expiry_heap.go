@@ -0,0 +1,38 @@
+package mapcache
+
+import "time"
+
+// expiryEntry is a single (key, expiresAt) pair tracked by expiryHeap.
+type expiryEntry[K comparable] struct {
+	key       K
+	expiresAt time.Time
+}
+
+// expiryHeap is a min-heap of expiryEntry ordered by expiresAt. It lets
+// cleanupRoutine find the next key to expire in O(log n) instead of
+// scanning the whole map on every tick.
+//
+// Entries are not removed when a key is refreshed or deleted early, so the
+// heap can contain stale entries for keys that have since been updated or
+// removed. Callers popping the root must check it against the map's current
+// UpdatedAt and discard it without deleting anything if it no longer
+// matches.
+type expiryHeap[K comparable] []expiryEntry[K]
+
+func (h expiryHeap[K]) Len() int { return len(h) }
+
+func (h expiryHeap[K]) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+
+func (h expiryHeap[K]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *expiryHeap[K]) Push(x any) {
+	*h = append(*h, x.(expiryEntry[K]))
+}
+
+func (h *expiryHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	*h = old[:n-1]
+	return entry
+}
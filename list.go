@@ -0,0 +1,63 @@
+package mapcache
+
+// listNode is a node in an intrusive doubly linked list used to track
+// eviction order for a MapCache key.
+type listNode[K comparable] struct {
+	key        K
+	prev, next *listNode[K]
+}
+
+// evictList is a doubly linked list of listNode, ordered from most-recent
+// (front) to least-recent (back). It backs both the LRU policy, which moves
+// a node to front on every hit, and the FIFO policy, which never reorders
+// after insertion, and is reused as the per-frequency bucket for LFU.
+type evictList[K comparable] struct {
+	front, back *listNode[K]
+	len         int
+}
+
+func (l *evictList[K]) pushFront(n *listNode[K]) {
+	n.prev = nil
+	n.next = l.front
+	if l.front != nil {
+		l.front.prev = n
+	}
+	l.front = n
+	if l.back == nil {
+		l.back = n
+	}
+	l.len++
+}
+
+func (l *evictList[K]) remove(n *listNode[K]) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		l.front = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		l.back = n.prev
+	}
+	n.prev, n.next = nil, nil
+	l.len--
+}
+
+func (l *evictList[K]) moveToFront(n *listNode[K]) {
+	if l.front == n {
+		return
+	}
+	l.remove(n)
+	l.pushFront(n)
+}
+
+// popBack removes and returns the least-recent node, or nil if the list is
+// empty.
+func (l *evictList[K]) popBack() *listNode[K] {
+	n := l.back
+	if n != nil {
+		l.remove(n)
+	}
+	return n
+}
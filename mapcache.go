@@ -1,6 +1,7 @@
 package mapcache
 
 import (
+	"container/heap"
 	"context"
 	"errors"
 	"fmt"
@@ -10,10 +11,33 @@ import (
 )
 
 type MapCache[K comparable, V any] struct {
-	m          map[K]Item[V]
-	mu         sync.RWMutex
-	TTL        time.Duration
-	cleanupCtx context.Context
+	m              map[K]Item[V]
+	mu             sync.RWMutex
+	TTL            time.Duration
+	cleanupCtx     context.Context
+	singleflight   bool
+	calls          map[K]*call[V]
+	expiries       expiryHeap[K]
+	cleanupEnabled bool
+
+	size   int
+	policy EvictionPolicy
+	order  *evictList[K]      // LRU / FIFO eviction order
+	nodes  map[K]*listNode[K] // key -> node in order, for LRU / FIFO
+	lfu    *lfuIndex[K]       // LFU bookkeeping
+
+	metrics  metricCounters
+	onEvict  func(K, V, EvictReason)
+	onInsert func(K, V)
+
+	codec Codec
+}
+
+type call[V any] struct {
+	wg       sync.WaitGroup
+	val      V
+	err      error
+	panicVal any
 }
 
 type Item[V any] struct {
@@ -22,14 +46,27 @@ type Item[V any] struct {
 }
 
 type options struct {
-	TTL             *time.Duration
-	Size            *int
-	CleanupInterval *time.Duration
-	CleanupCtx      context.Context
+	TTL            *time.Duration
+	Size           *int
+	CleanupCtx     context.Context
+	Singleflight   *bool
+	EvictionPolicy *EvictionPolicy
+	OnEvict        func(key, val any, reason EvictReason)
+	OnInsert       func(key, val any)
+	Codec          Codec
 }
 
 type OptFunc func(*options) error
 
+// WithSize caps the cache at size entries. Once the cache is at capacity,
+// inserting a new key evicts one existing entry according to the policy set
+// by WithEvictionPolicy (LRU by default).
+//
+// Under the LRU and LFU policies, every Get hit also takes mc's write lock
+// to record the recency/frequency update (see touch), so a size-bounded
+// LRU/LFU cache serializes reads the same as writes despite the RWMutex.
+// FIFO never reorders on a hit and keeps the usual RLock-only fast path; an
+// unbounded cache (no WithSize) is likewise unaffected.
 func WithSize(size int) OptFunc {
 	return func(o *options) error {
 		if size < 0 {
@@ -40,6 +77,17 @@ func WithSize(size int) OptFunc {
 	}
 }
 
+// WithSingleflight controls whether concurrent misses on the same key are
+// coalesced into a single call to up, with every caller receiving the same
+// result. It defaults to enabled; pass false to restore the old fan-out
+// behavior where every caller invokes up independently.
+func WithSingleflight(enabled bool) OptFunc {
+	return func(o *options) error {
+		o.Singleflight = &enabled
+		return nil
+	}
+}
+
 func WithTTL(ttl time.Duration) OptFunc {
 	return func(o *options) error {
 		if ttl < 0 {
@@ -50,13 +98,22 @@ func WithTTL(ttl time.Duration) OptFunc {
 	}
 }
 
-func WithCleanup(ctx context.Context, interval time.Duration) OptFunc {
+// WithCleanup enables a background goroutine that evicts expired entries.
+// Rather than polling, it wakes up when the next entry is due to expire, so
+// no interval argument is needed. Its first wake-up is one TTL after New, so
+// entries loaded by Restore that expire sooner are removed at that point. A
+// legacy interval may still be
+// passed for source compatibility, but it is ignored: timing is driven
+// entirely by the expiry heap.
+func WithCleanup(ctx context.Context, interval ...time.Duration) OptFunc {
 	return func(o *options) error {
-		if interval < 0 {
-			return fmt.Errorf("interval less than 0: %d", interval)
+		if len(interval) > 1 {
+			return fmt.Errorf("WithCleanup accepts at most one interval argument")
+		}
+		if len(interval) == 1 && interval[0] < 0 {
+			return fmt.Errorf("interval less than 0: %d", interval[0])
 		}
 		o.CleanupCtx = ctx
-		o.CleanupInterval = &interval
 		return nil
 	}
 }
@@ -71,49 +128,139 @@ func New[K comparable, V any](opts ...OptFunc) (*MapCache[K, V], error) {
 	var mc MapCache[K, V]
 	if o.Size != nil {
 		mc.m = make(map[K]Item[V], *o.Size)
+		mc.size = *o.Size
 	} else {
 		mc.m = make(map[K]Item[V])
 	}
 	if o.TTL != nil {
 		mc.TTL = *o.TTL
 	}
-	if o.CleanupInterval != nil {
-		if err := mc.cleanupRoutine(o.CleanupCtx, *o.CleanupInterval); err != nil {
+	mc.singleflight = true
+	if o.Singleflight != nil {
+		mc.singleflight = *o.Singleflight
+	}
+	mc.calls = make(map[K]*call[V])
+	if mc.size > 0 {
+		mc.policy = LRU
+		if o.EvictionPolicy != nil {
+			mc.policy = *o.EvictionPolicy
+		}
+		if mc.policy == LFU {
+			mc.lfu = newLFUIndex[K]()
+		} else {
+			mc.order = &evictList[K]{}
+			mc.nodes = make(map[K]*listNode[K], mc.size)
+		}
+	}
+	if o.OnEvict != nil {
+		onEvict := o.OnEvict
+		mc.onEvict = func(k K, v V, r EvictReason) { onEvict(k, v, r) }
+	}
+	if o.OnInsert != nil {
+		onInsert := o.OnInsert
+		mc.onInsert = func(k K, v V) { onInsert(k, v) }
+	}
+	mc.codec = o.Codec
+	if o.CleanupCtx != nil {
+		mc.cleanupEnabled = true
+		if err := mc.cleanupRoutine(o.CleanupCtx); err != nil {
 			return nil, err
 		}
-
 	}
 	return &mc, nil
 }
 
-func (mc *MapCache[K, V]) cleanupRoutine(ctx context.Context, interval time.Duration) error {
+// Metrics returns a point-in-time snapshot of the cache's activity counters.
+func (mc *MapCache[K, V]) Metrics() Metrics {
+	mc.mu.RLock()
+	size := len(mc.m)
+	mc.mu.RUnlock()
+	return mc.metrics.snapshot(size)
+}
+
+// Delete removes key from the cache, if present, firing the OnEvict
+// callback with EvictReasonManual. It reports whether the key was present.
+func (mc *MapCache[K, V]) Delete(key K) bool {
+	mc.mu.Lock()
+	item, ok := mc.m[key]
+	if ok {
+		delete(mc.m, key)
+		mc.untrackLocked(key)
+		mc.metrics.evictions.Add(1)
+	}
+	mc.mu.Unlock()
+	if ok {
+		mc.fireEvict(key, item.V, EvictReasonManual)
+	}
+	return ok
+}
+
+func (mc *MapCache[K, V]) fireEvict(key K, val V, reason EvictReason) {
+	if mc.onEvict != nil {
+		mc.onEvict(key, val, reason)
+	}
+}
+
+func (mc *MapCache[K, V]) fireInsert(key K, val V) {
+	if mc.onInsert != nil {
+		mc.onInsert(key, val)
+	}
+}
+
+func (mc *MapCache[K, V]) cleanupRoutine(ctx context.Context) error {
 	if mc.TTL == 0 {
 		return errors.New("WithCleanup option is not valid for TTL 0 (value lives forever)")
 	}
 	if mc.TTL < 0 {
 		return errors.New("withCleanup option is not valid for TTL less than 0")
 	}
+	timer := time.NewTimer(mc.TTL)
 	go func() {
+		defer timer.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-
-			case <-time.After(interval):
-				now := time.Now()
-				mc.mu.Lock()
-				for k, v := range mc.m {
-					if now.Sub(v.UpdatedAt) > mc.TTL {
-						delete(mc.m, k)
-					}
-				}
-				mc.mu.Unlock()
+			case <-timer.C:
+				timer.Reset(mc.expireDue())
 			}
 		}
 	}()
 	return nil
 }
 
+// expireDue pops and deletes every heap-expired entry whose heap timestamp
+// still matches the map's current UpdatedAt, then returns how long the
+// caller should wait before the next entry is due to expire. OnEvict is
+// fired for each removed entry only after mc.mu is released.
+func (mc *MapCache[K, V]) expireDue() time.Duration {
+	now := time.Now()
+	var expired []evictedEntry[K, V]
+	wait := mc.TTL
+
+	mc.mu.Lock()
+	for mc.expiries.Len() > 0 {
+		next := mc.expiries[0]
+		if next.expiresAt.After(now) {
+			wait = next.expiresAt.Sub(now)
+			break
+		}
+		heap.Pop(&mc.expiries)
+		if item, ok := mc.m[next.key]; ok && item.UpdatedAt.Add(mc.TTL).Equal(next.expiresAt) {
+			delete(mc.m, next.key)
+			mc.untrackLocked(next.key)
+			mc.metrics.evictions.Add(1)
+			expired = append(expired, evictedEntry[K, V]{key: next.key, val: item.V})
+		}
+	}
+	mc.mu.Unlock()
+
+	for _, e := range expired {
+		mc.fireEvict(e.key, e.val, EvictReasonExpired)
+	}
+	return wait
+}
+
 func (mc *MapCache[K, V]) Get(key K, up func() (V, error), opts ...OptFunc) (V, error) {
 	var o options
 	for _, opt := range opts {
@@ -128,17 +275,8 @@ func (mc *MapCache[K, V]) Get(key K, up func() (V, error), opts ...OptFunc) (V,
 	mc.mu.RUnlock()
 	now := time.Now()
 	if !ok {
-		newVal, err := up()
-		if err != nil {
-			return newVal, err
-		}
-		mc.mu.Lock()
-		mc.m[key] = Item[V]{
-			V:         newVal,
-			UpdatedAt: now,
-		}
-		mc.mu.Unlock()
-		return newVal, nil
+		mc.metrics.misses.Add(1)
+		return mc.update(key, up)
 	}
 	ttl := mc.TTL
 	if o.TTL != nil {
@@ -146,37 +284,230 @@ func (mc *MapCache[K, V]) Get(key K, up func() (V, error), opts ...OptFunc) (V,
 	}
 
 	if ttl == 0 {
+		mc.metrics.hits.Add(1)
+		mc.touch(key)
 		return item.V, nil
 	}
 	age := now.Sub(item.UpdatedAt)
 	if age < ttl {
+		mc.metrics.hits.Add(1)
+		mc.touch(key)
 		return item.V, nil
 	}
-	newVal, err := up()
-	if err != nil {
-		return newVal, err
+	mc.metrics.misses.Add(1)
+	return mc.update(key, up)
+}
+
+// update invokes up to refresh key and stores the result, coalescing
+// concurrent callers for the same key into a single call to up when
+// singleflight is enabled.
+func (mc *MapCache[K, V]) update(key K, up func() (V, error)) (V, error) {
+	if !mc.singleflight {
+		newVal, err := up()
+		if err != nil {
+			mc.metrics.updaterErrors.Add(1)
+			return newVal, err
+		}
+		now := time.Now()
+		mc.mu.Lock()
+		evicted, inserted := mc.storeLocked(key, newVal, now)
+		mc.mu.Unlock()
+		mc.fireStoreResult(evicted, key, newVal, inserted)
+		return newVal, nil
 	}
+
 	mc.mu.Lock()
-	mc.m[key] = Item[V]{
-		V:         newVal,
-		UpdatedAt: now,
+	if c, ok := mc.calls[key]; ok {
+		mc.mu.Unlock()
+		c.wg.Wait()
+		if c.panicVal != nil {
+			panic(c.panicVal)
+		}
+		return c.val, c.err
 	}
+	c := &call[V]{}
+	c.wg.Add(1)
+	mc.calls[key] = c
 	mc.mu.Unlock()
-	return newVal, nil
+
+	mc.callUp(c, key, up)
+	if c.panicVal != nil {
+		panic(c.panicVal)
+	}
+
+	return c.val, c.err
 }
 
-func (mc *MapCache[K, V]) AllParallel() iter.Seq2[K, Item[V]] {
-	return func(yield func(K, Item[V]) bool) {
-		mc.mu.RLock()
-		defer mc.mu.RUnlock()
-		for k, v := range mc.m {
-			go func() {
-				yield(k, v)
-			}()
+// callUp runs up for the singleflight leader of key, storing its result in
+// c and always releasing c's waiters afterward. Matching
+// golang.org/x/sync/singleflight's panic semantics, a panic inside up is
+// recovered here, recorded in c.panicVal, and re-panicked by every goroutine
+// waiting on c (in update) rather than left to unwind with mc.calls[key] and
+// c.wg stuck: without this, a panicking updater would permanently deadlock
+// every future Get on key, since nothing would ever remove the call entry
+// or release the waitgroup.
+func (mc *MapCache[K, V]) callUp(c *call[V], key K, up func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.panicVal = r
+			mc.metrics.updaterErrors.Add(1)
+		}
+
+		mc.mu.Lock()
+		var evicted *evictedEntry[K, V]
+		var inserted bool
+		if c.panicVal == nil && c.err == nil {
+			evicted, inserted = mc.storeLocked(key, c.val, time.Now())
 		}
+		delete(mc.calls, key)
+		mc.mu.Unlock()
+		mc.fireStoreResult(evicted, key, c.val, inserted)
+
+		c.wg.Done()
+	}()
+
+	c.val, c.err = up()
+	if c.err != nil {
+		mc.metrics.updaterErrors.Add(1)
 	}
 }
 
+// fireStoreResult delivers the OnEvict and OnInsert callbacks for a store
+// that just completed. It must be called with mc.mu released.
+func (mc *MapCache[K, V]) fireStoreResult(evicted *evictedEntry[K, V], key K, val V, inserted bool) {
+	if evicted != nil {
+		mc.fireEvict(evicted.key, evicted.val, EvictReasonCapacity)
+	}
+	if inserted {
+		mc.fireInsert(key, val)
+	}
+}
+
+// pushExpiry records that key will next expire at updatedAt+TTL, for the
+// cleanup routine to pick up later. Callers must hold mc.mu. It is a no-op
+// when the cache has no TTL or WithCleanup wasn't used: expireDue is the
+// heap's only consumer, so without it nothing would ever pop entries and
+// the heap would grow without bound under key churn (TTL still applies on
+// the read path in Get regardless).
+func (mc *MapCache[K, V]) pushExpiry(key K, updatedAt time.Time) {
+	if mc.TTL <= 0 || !mc.cleanupEnabled {
+		return
+	}
+	heap.Push(&mc.expiries, expiryEntry[K]{key: key, expiresAt: updatedAt.Add(mc.TTL)})
+}
+
+// storeLocked writes val for key, refreshing its expiry and eviction
+// bookkeeping, and bumps the relevant metrics counters. It reports the
+// entry evicted to make room, if any, and whether key is a new insertion;
+// callers must deliver the corresponding OnEvict/OnInsert callbacks
+// themselves once mc.mu is released. Callers must hold mc.mu.
+func (mc *MapCache[K, V]) storeLocked(key K, val V, now time.Time) (evicted *evictedEntry[K, V], inserted bool) {
+	_, existed := mc.m[key]
+	if mc.size > 0 {
+		if existed {
+			mc.touchLocked(key)
+		} else {
+			evicted = mc.evictIfFullLocked()
+		}
+	}
+	mc.m[key] = Item[V]{V: val, UpdatedAt: now}
+	mc.pushExpiry(key, now)
+	if !existed {
+		if mc.size > 0 {
+			mc.trackInsertLocked(key)
+		}
+		mc.metrics.insertions.Add(1)
+		inserted = true
+	}
+	return evicted, inserted
+}
+
+// evictIfFullLocked drops one entry according to the configured eviction
+// policy if the cache is already at its size cap, reporting it so the
+// caller can fire OnEvict once mc.mu is released. Callers must hold mc.mu.
+func (mc *MapCache[K, V]) evictIfFullLocked() *evictedEntry[K, V] {
+	if len(mc.m) < mc.size {
+		return nil
+	}
+	var key K
+	if mc.policy == LFU {
+		k, ok := mc.lfu.evict()
+		if !ok {
+			return nil
+		}
+		key = k
+	} else {
+		n := mc.order.popBack()
+		if n == nil {
+			return nil
+		}
+		key = n.key
+		delete(mc.nodes, key)
+	}
+	val := mc.m[key].V
+	delete(mc.m, key)
+	mc.metrics.evictions.Add(1)
+	return &evictedEntry[K, V]{key: key, val: val}
+}
+
+// trackInsertLocked registers a newly-inserted key with the eviction
+// policy's bookkeeping. Callers must hold mc.mu.
+func (mc *MapCache[K, V]) trackInsertLocked(key K) {
+	if mc.policy == LFU {
+		mc.lfu.insert(key)
+		return
+	}
+	n := &listNode[K]{key: key}
+	mc.order.pushFront(n)
+	mc.nodes[key] = n
+}
+
+// untrackLocked removes key from the eviction policy's bookkeeping, e.g.
+// when it expires via the cleanup routine rather than through storeLocked.
+// Callers must hold mc.mu.
+func (mc *MapCache[K, V]) untrackLocked(key K) {
+	if mc.size <= 0 {
+		return
+	}
+	if mc.policy == LFU {
+		mc.lfu.remove(key)
+		return
+	}
+	if n, ok := mc.nodes[key]; ok {
+		mc.order.remove(n)
+		delete(mc.nodes, key)
+	}
+}
+
+// touchLocked records a cache hit on key for recency/frequency purposes.
+// Callers must hold mc.mu.
+func (mc *MapCache[K, V]) touchLocked(key K) {
+	switch mc.policy {
+	case LFU:
+		mc.lfu.touch(key)
+	case FIFO:
+		// insertion order never changes on a hit.
+	default: // LRU
+		if n, ok := mc.nodes[key]; ok {
+			mc.order.moveToFront(n)
+		}
+	}
+}
+
+// touch records a cache hit on key for recency/frequency purposes. It is a
+// no-op for unbounded caches and for the FIFO policy, but for LRU/LFU it
+// takes mc's full write lock on every call: Get's hit path otherwise only
+// needs an RLock, so a size-bounded LRU/LFU cache pays full mutual
+// exclusion on every read, not just every write.
+func (mc *MapCache[K, V]) touch(key K) {
+	if mc.size <= 0 || mc.policy == FIFO {
+		return
+	}
+	mc.mu.Lock()
+	mc.touchLocked(key)
+	mc.mu.Unlock()
+}
+
 func (mc *MapCache[K, V]) All() iter.Seq2[K, Item[V]] {
 	return func(yield func(K, Item[V]) bool) {
 		mc.mu.RLock()
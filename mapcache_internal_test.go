@@ -0,0 +1,28 @@
+package mapcache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMapCache_NoCleanupDoesNotGrowExpiryHeap guards against the expiry
+// heap accumulating an entry per Get-driven refresh when WithCleanup was
+// never requested, since expireDue (the heap's only consumer) never runs
+// in that configuration.
+func TestMapCache_NoCleanupDoesNotGrowExpiryHeap(t *testing.T) {
+	mc, err := New[string, int](WithTTL(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		if _, err := mc.Get("key", func() (int, error) { return i, nil }); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	if got := mc.expiries.Len(); got != 0 {
+		t.Errorf("expected expiry heap to stay empty without WithCleanup, got %d entries", got)
+	}
+}
@@ -3,6 +3,8 @@ package mapcache_test
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -135,6 +137,92 @@ func TestMapCache_Get(t *testing.T) {
 		}
 	})
 
+	t.Run("singleflight coalesces concurrent misses", func(t *testing.T) {
+		mc, err := mapcache.New[string, int]()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var calls int32
+		start := make(chan struct{})
+		updater := func() (int, error) {
+			atomic.AddInt32(&calls, 1)
+			<-start
+			return 7, nil
+		}
+
+		const n = 10
+		results := make([]int, n)
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		wg.Add(n)
+		for i := 0; i < n; i++ {
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = mc.Get("shared", updater)
+			}(i)
+		}
+
+		// Give every goroutine a chance to observe the miss before the
+		// updater is allowed to return.
+		time.Sleep(20 * time.Millisecond)
+		close(start)
+		wg.Wait()
+
+		if got := atomic.LoadInt32(&calls); got != 1 {
+			t.Errorf("expected updater to be called once, got %d", got)
+		}
+		for i, r := range results {
+			if r != 7 || errs[i] != nil {
+				t.Errorf("caller %d: expected (7, nil), got (%d, %v)", i, r, errs[i])
+			}
+		}
+	})
+
+	t.Run("singleflight recovers from a panicking updater", func(t *testing.T) {
+		mc, err := mapcache.New[string, int]()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start := make(chan struct{})
+		panicker := func() (int, error) {
+			<-start
+			panic("updater blew up")
+		}
+
+		const n = 5
+		done := make(chan any, n)
+		var wg sync.WaitGroup
+		wg.Add(n)
+		for i := 0; i < n; i++ {
+			go func() {
+				defer wg.Done()
+				defer func() { done <- recover() }()
+				_, _ = mc.Get("shared", panicker)
+			}()
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		close(start)
+		wg.Wait()
+		close(done)
+
+		for r := range done {
+			if r == nil {
+				t.Error("expected every waiter to observe the updater's panic, got none")
+			}
+		}
+
+		// A panicking updater must not leave the call entry or its
+		// waitgroup stuck: a subsequent Get on the same key should
+		// proceed normally rather than deadlock.
+		val, err := mc.Get("shared", func() (int, error) { return 42, nil })
+		if err != nil || val != 42 {
+			t.Errorf("expected (42, nil) after recovery, got (%d, %v)", val, err)
+		}
+	})
+
 	t.Run("updater error", func(t *testing.T) {
 		mc, err := mapcache.New[string, int]()
 		if err != nil {
@@ -189,6 +277,37 @@ func TestMapCache_Cleanup(t *testing.T) {
 	}
 }
 
+func TestMapCache_CleanupNoInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mc, err := mapcache.New[string, int](
+		mapcache.WithTTL(50*time.Millisecond),
+		mapcache.WithCleanup(ctx),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updater := func() (int, error) {
+		return 42, nil
+	}
+
+	if _, err := mc.Get("test", updater); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	var count int
+	for range mc.All() {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("expected 0 items after cleanup, got %d", count)
+	}
+}
+
 func TestMapCache_All(t *testing.T) {
 	mc, err := mapcache.New[string, int]()
 	if err != nil {
@@ -0,0 +1,82 @@
+package mapcache
+
+import "sync/atomic"
+
+// EvictReason identifies why an entry left the cache, passed to the
+// callback registered with WithOnEvict.
+type EvictReason int
+
+const (
+	// EvictReasonExpired means the entry's TTL elapsed.
+	EvictReasonExpired EvictReason = iota
+	// EvictReasonCapacity means the entry was evicted to make room under a
+	// size cap (see WithSize).
+	EvictReasonCapacity
+	// EvictReasonManual means the entry was removed by an explicit Delete
+	// call.
+	EvictReasonManual
+)
+
+// Metrics is a point-in-time snapshot of a MapCache's activity counters.
+type Metrics struct {
+	Hits          int64
+	Misses        int64
+	Evictions     int64
+	Insertions    int64
+	UpdaterErrors int64
+	CurrentSize   int64
+}
+
+// metricCounters holds the atomic counters backing MapCache.Metrics.
+type metricCounters struct {
+	hits          atomic.Int64
+	misses        atomic.Int64
+	evictions     atomic.Int64
+	insertions    atomic.Int64
+	updaterErrors atomic.Int64
+}
+
+func (c *metricCounters) snapshot(currentSize int) Metrics {
+	return Metrics{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     c.evictions.Load(),
+		Insertions:    c.insertions.Load(),
+		UpdaterErrors: c.updaterErrors.Load(),
+		CurrentSize:   int64(currentSize),
+	}
+}
+
+// evictedEntry is a (key, value) pair removed from the cache, queued up for
+// delivery to the OnEvict callback once the caller has released mc.mu.
+type evictedEntry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// WithOnEvict registers a callback invoked whenever an entry leaves the
+// cache, whether by TTL expiry, capacity eviction, or manual Delete. It is
+// always called outside mc's internal lock, so it is safe for the callback
+// to call back into the cache.
+//
+// options is not itself generic, so the callback is stored type-erased and
+// recovered by New once K and V are known.
+func WithOnEvict[K comparable, V any](fn func(K, V, EvictReason)) OptFunc {
+	return func(o *options) error {
+		o.OnEvict = func(key, val any, reason EvictReason) {
+			fn(key.(K), val.(V), reason)
+		}
+		return nil
+	}
+}
+
+// WithOnInsert registers a callback invoked whenever a new key is inserted
+// into the cache. It is always called outside mc's internal lock.
+func WithOnInsert[K comparable, V any](fn func(K, V)) OptFunc {
+	return func(o *options) error {
+		o.OnInsert = func(key, val any) {
+			fn(key.(K), val.(V))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package mapcache_test
+
+import (
+	"testing"
+
+	mapcache "github.com/omniaura/mapcache"
+)
+
+func TestMapCache_Metrics(t *testing.T) {
+	mc, err := mapcache.New[string, int](mapcache.WithSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	// Evicts "a" to make room for "b".
+	if _, err := mc.Get("b", value(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	m := mc.Metrics()
+	if m.Insertions != 2 {
+		t.Errorf("expected 2 insertions, got %d", m.Insertions)
+	}
+	if m.Hits != 1 {
+		t.Errorf("expected 1 hit, got %d", m.Hits)
+	}
+	if m.Misses != 2 {
+		t.Errorf("expected 2 misses, got %d", m.Misses)
+	}
+	if m.Evictions != 1 {
+		t.Errorf("expected 1 eviction, got %d", m.Evictions)
+	}
+	if m.CurrentSize != 1 {
+		t.Errorf("expected current size 1, got %d", m.CurrentSize)
+	}
+}
+
+func TestMapCache_OnEvictOnInsert(t *testing.T) {
+	var inserted []string
+	var evicted []string
+	var reasons []mapcache.EvictReason
+
+	mc, err := mapcache.New[string, int](
+		mapcache.WithSize(1),
+		mapcache.WithOnInsert(func(k string, v int) {
+			inserted = append(inserted, k)
+		}),
+		mapcache.WithOnEvict(func(k string, v int, reason mapcache.EvictReason) {
+			evicted = append(evicted, k)
+			reasons = append(reasons, reason)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("b", value(2)); err != nil {
+		t.Fatal(err)
+	}
+	mc.Delete("b")
+
+	if len(inserted) != 2 || inserted[0] != "a" || inserted[1] != "b" {
+		t.Errorf("expected inserts [a b], got %v", inserted)
+	}
+	if len(evicted) != 2 || evicted[0] != "a" || evicted[1] != "b" {
+		t.Errorf("expected evictions [a b], got %v", evicted)
+	}
+	if len(reasons) != 2 || reasons[0] != mapcache.EvictReasonCapacity || reasons[1] != mapcache.EvictReasonManual {
+		t.Errorf("expected reasons [capacity manual], got %v", reasons)
+	}
+}
@@ -0,0 +1,101 @@
+package mapcache
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// snapshot copies the cache's current keys and items under a single read
+// lock, for use by the parallel iterators below.
+func (mc *MapCache[K, V]) snapshot() ([]K, []Item[V]) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	keys := make([]K, 0, len(mc.m))
+	items := make([]Item[V], 0, len(mc.m))
+	for k, v := range mc.m {
+		keys = append(keys, k)
+		items = append(items, v)
+	}
+	return keys, items
+}
+
+// AllParallel returns an iterator over a snapshot of the cache, dispatching
+// each (key, item) pair to a bounded pool of workers goroutines. Calls to
+// yield are serialized, so yield itself need not be concurrency-safe.
+// Iteration stops once yield returns false or ctx is canceled, though
+// workers already running finish their current item first.
+func (mc *MapCache[K, V]) AllParallel(ctx context.Context, workers int) iter.Seq2[K, Item[V]] {
+	if workers < 1 {
+		workers = 1
+	}
+	return func(yield func(K, Item[V]) bool) {
+		keys, items := mc.snapshot()
+
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		stopped := false
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for idx := range jobs {
+					mu.Lock()
+					if !stopped && !yield(keys[idx], items[idx]) {
+						stopped = true
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+
+	dispatch:
+		for idx := range keys {
+			select {
+			case <-ctx.Done():
+				break dispatch
+			case jobs <- idx:
+				mu.Lock()
+				done := stopped
+				mu.Unlock()
+				if done {
+					break dispatch
+				}
+			}
+		}
+		close(jobs)
+		wg.Wait()
+	}
+}
+
+// MapParallel applies fn to every (key, item) pair in mc using a bounded
+// pool of workers goroutines, returning the results in unspecified order.
+// This is a package-level function rather than a method because Go methods
+// cannot introduce their own type parameter (V2) beyond the receiver's.
+func MapParallel[K comparable, V any, V2 any](mc *MapCache[K, V], workers int, fn func(K, Item[V]) V2) []V2 {
+	if workers < 1 {
+		workers = 1
+	}
+	keys, items := mc.snapshot()
+
+	results := make([]V2, len(keys))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = fn(keys[idx], items[idx])
+			}
+		}()
+	}
+	for idx := range keys {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,57 @@
+package mapcache_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	mapcache "github.com/omniaura/mapcache"
+)
+
+func TestMapCache_AllParallel(t *testing.T) {
+	mc, err := mapcache.New[string, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]int{"a": 1, "b": 2, "c": 3} {
+		if _, err := mc.Get(k, value(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	var seen []string
+	for k, item := range mc.AllParallel(context.Background(), 2) {
+		mu.Lock()
+		seen = append(seen, k)
+		mu.Unlock()
+		_ = item
+	}
+
+	sort.Strings(seen)
+	if got := seen; len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("expected [a b c], got %v", got)
+	}
+}
+
+func TestMapParallel(t *testing.T) {
+	mc, err := mapcache.New[string, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]int{"a": 1, "b": 2, "c": 3} {
+		if _, err := mc.Get(k, value(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := mapcache.MapParallel(mc, 2, func(k string, item mapcache.Item[int]) int {
+		return item.V * 10
+	})
+
+	sort.Ints(results)
+	if len(results) != 3 || results[0] != 10 || results[1] != 20 || results[2] != 30 {
+		t.Errorf("expected [10 20 30], got %v", results)
+	}
+}
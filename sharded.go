@@ -0,0 +1,200 @@
+package mapcache
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"iter"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+// Hasher maps a key to a shard index hash for Sharded. Supply one via
+// NewSharded for hot paths or key types that don't stringify uniquely; the
+// default hashes fmt.Sprintf("%v", key) with FNV-32a.
+type Hasher[K comparable] func(K) uint32
+
+func defaultHasher[K comparable](key K) uint32 {
+	h := fnv.New32a()
+	_, _ = io.WriteString(h, fmt.Sprintf("%v", key))
+	return h.Sum32()
+}
+
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// Sharded wraps N independent MapCache instances, splitting keys across
+// them by hash so that a single global RWMutex doesn't become the
+// bottleneck under write-heavy, highly concurrent workloads. Get, Delete,
+// All, AllParallel, Metrics, and Snapshot/RestoreSharded mirror the
+// corresponding MapCache methods, aggregating transparently across shards.
+//
+// MapCache's two package-level generic functions don't have a direct
+// Sharded method equivalent, since Go methods can't add their own type
+// parameter: use MapParallelSharded in place of MapParallel, and
+// RestoreSharded in place of Restore.
+type Sharded[K comparable, V any] struct {
+	shards []*MapCache[K, V]
+	hasher Hasher[K]
+}
+
+// NewSharded creates a Sharded cache of the given number of shards, each
+// built with opts exactly as New would build a single MapCache. If shards
+// is <= 0, it defaults to runtime.GOMAXPROCS(0) rounded up to the next
+// power of two. A nil hasher uses the default FNV-32a hasher.
+func NewSharded[K comparable, V any](shards int, hasher Hasher[K], opts ...OptFunc) (*Sharded[K, V], error) {
+	if shards <= 0 {
+		shards = nextPowerOfTwo(runtime.GOMAXPROCS(0))
+	}
+	if hasher == nil {
+		hasher = defaultHasher[K]
+	}
+	sc := &Sharded[K, V]{
+		shards: make([]*MapCache[K, V], shards),
+		hasher: hasher,
+	}
+	for i := range sc.shards {
+		mc, err := New[K, V](opts...)
+		if err != nil {
+			return nil, err
+		}
+		sc.shards[i] = mc
+	}
+	return sc, nil
+}
+
+func (sc *Sharded[K, V]) shardFor(key K) *MapCache[K, V] {
+	idx := sc.hasher(key) % uint32(len(sc.shards))
+	return sc.shards[idx]
+}
+
+// Get delegates to the shard key hashes to; see MapCache.Get.
+func (sc *Sharded[K, V]) Get(key K, up func() (V, error), opts ...OptFunc) (V, error) {
+	return sc.shardFor(key).Get(key, up, opts...)
+}
+
+// Delete delegates to the shard key hashes to; see MapCache.Delete.
+func (sc *Sharded[K, V]) Delete(key K) bool {
+	return sc.shardFor(key).Delete(key)
+}
+
+// Metrics returns the sum of every shard's Metrics.
+func (sc *Sharded[K, V]) Metrics() Metrics {
+	var agg Metrics
+	for _, shard := range sc.shards {
+		m := shard.Metrics()
+		agg.Hits += m.Hits
+		agg.Misses += m.Misses
+		agg.Evictions += m.Evictions
+		agg.Insertions += m.Insertions
+		agg.UpdaterErrors += m.UpdaterErrors
+		agg.CurrentSize += m.CurrentSize
+	}
+	return agg
+}
+
+// All iterates every entry across all shards.
+func (sc *Sharded[K, V]) All() iter.Seq2[K, Item[V]] {
+	return func(yield func(K, Item[V]) bool) {
+		for _, shard := range sc.shards {
+			for k, v := range shard.All() {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AllParallel iterates every entry across all shards, running each shard's
+// own bounded worker pool in turn; see MapCache.AllParallel.
+func (sc *Sharded[K, V]) AllParallel(ctx context.Context, workers int) iter.Seq2[K, Item[V]] {
+	return func(yield func(K, Item[V]) bool) {
+		for _, shard := range sc.shards {
+			for k, v := range shard.AllParallel(ctx, workers) {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// codec returns the codec shared by every shard (all shards are built from
+// the same opts, so the same WithCodec choice applies to all of them).
+func (sc *Sharded[K, V]) codec() Codec {
+	if len(sc.shards) == 0 {
+		return GobCodec{}
+	}
+	return sc.shards[0].codecOrDefault()
+}
+
+// Snapshot writes every entry across all shards to w using the codec
+// selected by WithCodec (GobCodec by default), for later use with
+// RestoreSharded.
+func (sc *Sharded[K, V]) Snapshot(w io.Writer) error {
+	var entries []snapshotEntry[K, V]
+	for _, shard := range sc.shards {
+		for k, item := range shard.All() {
+			entries = append(entries, snapshotEntry[K, V]{Key: k, Value: item.V, UpdatedAt: item.UpdatedAt})
+		}
+	}
+	return sc.codec().Encode(w, entries)
+}
+
+// RestoreSharded builds a new Sharded cache from a snapshot written by
+// Sharded.Snapshot, distributing entries across shards the same way Get
+// would. shards, hasher, and opts behave exactly as in NewSharded; entries
+// are dropped or evicted on load the same way Restore does for a single
+// MapCache.
+func RestoreSharded[K comparable, V any](r io.Reader, shards int, hasher Hasher[K], opts ...OptFunc) (*Sharded[K, V], error) {
+	sc, err := NewSharded[K, V](shards, hasher, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []snapshotEntry[K, V]
+	if err := sc.codec().Decode(r, &entries); err != nil {
+		return nil, err
+	}
+
+	byShard := make([][]snapshotEntry[K, V], len(sc.shards))
+	for _, e := range entries {
+		idx := sc.hasher(e.Key) % uint32(len(sc.shards))
+		byShard[idx] = append(byShard[idx], e)
+	}
+	for i, shard := range sc.shards {
+		shard.loadEntries(byShard[i])
+	}
+	return sc, nil
+}
+
+// MapParallelSharded applies fn to every (key, item) pair across all of
+// sc's shards, running each shard's own bounded worker pool concurrently,
+// and returns the combined results in unspecified order. It stands in for
+// MapParallel, which takes a *MapCache and so cannot be called on Sharded
+// directly.
+func MapParallelSharded[K comparable, V any, V2 any](sc *Sharded[K, V], workers int, fn func(K, Item[V]) V2) []V2 {
+	perShard := make([][]V2, len(sc.shards))
+	var wg sync.WaitGroup
+	wg.Add(len(sc.shards))
+	for i, shard := range sc.shards {
+		go func(i int, shard *MapCache[K, V]) {
+			defer wg.Done()
+			perShard[i] = MapParallel(shard, workers, fn)
+		}(i, shard)
+	}
+	wg.Wait()
+
+	var results []V2
+	for _, r := range perShard {
+		results = append(results, r...)
+	}
+	return results
+}
@@ -0,0 +1,145 @@
+package mapcache_test
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+
+	mapcache "github.com/omniaura/mapcache"
+)
+
+func TestSharded_GetAndAllAcrossShards(t *testing.T) {
+	sc, err := mapcache.NewSharded[string, int](4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]int{}
+	for i := 0; i < 20; i++ {
+		k := fmt.Sprintf("key-%d", i)
+		want[k] = i
+		if _, err := sc.Get(k, value(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := map[string]int{}
+	for k, item := range sc.All() {
+		got[k] = item.V
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(got))
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("key %s: expected %d, got %d", k, v, got[k])
+		}
+	}
+
+	if m := sc.Metrics(); m.Insertions != int64(len(want)) {
+		t.Errorf("expected %d insertions, got %d", len(want), m.Insertions)
+	}
+}
+
+func TestSharded_SnapshotRestore(t *testing.T) {
+	sc, err := mapcache.NewSharded[string, int](4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"a": 1, "b": 2, "c": 3}
+	for k, v := range want {
+		if _, err := sc.Get(k, value(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := sc.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := mapcache.RestoreSharded[string, int](&buf, 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]int{}
+	for k, item := range restored.All() {
+		got[k] = item.V
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(got))
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("key %s: expected %d, got %d", k, v, got[k])
+		}
+	}
+}
+
+func TestMapParallelSharded(t *testing.T) {
+	sc, err := mapcache.NewSharded[string, int](4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]int{"a": 1, "b": 2, "c": 3} {
+		if _, err := sc.Get(k, value(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := mapcache.MapParallelSharded(sc, 2, func(k string, item mapcache.Item[int]) int {
+		return item.V * 10
+	})
+
+	sort.Ints(results)
+	if len(results) != 3 || results[0] != 10 || results[1] != 20 || results[2] != 30 {
+		t.Errorf("expected [10 20 30], got %v", results)
+	}
+}
+
+func benchmarkConcurrentGet(b *testing.B, get func(key string) (int, error)) {
+	b.ReportAllocs()
+	goroutines := runtime.GOMAXPROCS(0)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	start := make(chan struct{})
+	perGoroutine := b.N / goroutines
+	if perGoroutine == 0 {
+		perGoroutine = 1
+	}
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			<-start
+			for i := 0; i < perGoroutine; i++ {
+				k := fmt.Sprintf("key-%d", (g*perGoroutine+i)%64)
+				_, _ = get(k)
+			}
+		}(g)
+	}
+	b.ResetTimer()
+	close(start)
+	wg.Wait()
+}
+
+// BenchmarkSharded_ConcurrentGet compares 1 shard (equivalent to a plain
+// MapCache behind the Sharded API) against increasing shard counts, to
+// isolate the effect of shard count on lock contention from the effect of
+// going through the Sharded wrapper at all.
+func BenchmarkSharded_ConcurrentGet(b *testing.B) {
+	for _, shards := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			sc, err := mapcache.NewSharded[string, int](shards, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			benchmarkConcurrentGet(b, func(key string) (int, error) {
+				return sc.Get(key, value(1))
+			})
+		})
+	}
+}
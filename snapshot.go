@@ -0,0 +1,122 @@
+package mapcache
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Codec encodes and decodes a MapCache's persisted state for Snapshot and
+// Restore. Implement it to plug in a format other than the GobCodec
+// default, e.g. JSONCodec or a MessagePack-backed codec.
+type Codec interface {
+	Encode(w io.Writer, v any) error
+	Decode(r io.Reader, v any) error
+}
+
+// GobCodec serializes using encoding/gob. It is the default codec for
+// Snapshot and Restore.
+type GobCodec struct{}
+
+func (GobCodec) Encode(w io.Writer, v any) error { return gob.NewEncoder(w).Encode(v) }
+
+func (GobCodec) Decode(r io.Reader, v any) error { return gob.NewDecoder(r).Decode(v) }
+
+// JSONCodec serializes using encoding/json, trading compactness for a
+// human-readable snapshot format.
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(w io.Writer, v any) error { return json.NewEncoder(w).Encode(v) }
+
+func (JSONCodec) Decode(r io.Reader, v any) error { return json.NewDecoder(r).Decode(v) }
+
+// WithCodec selects the codec used by Snapshot and Restore. It defaults to
+// GobCodec.
+func WithCodec(c Codec) OptFunc {
+	return func(o *options) error {
+		o.Codec = c
+		return nil
+	}
+}
+
+// snapshotEntry is the on-the-wire representation of one cache entry.
+type snapshotEntry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	UpdatedAt time.Time
+}
+
+// Snapshot writes every entry in the cache to w using the codec selected by
+// WithCodec (GobCodec by default), for later use with Restore.
+func (mc *MapCache[K, V]) Snapshot(w io.Writer) error {
+	mc.mu.RLock()
+	entries := make([]snapshotEntry[K, V], 0, len(mc.m))
+	for k, item := range mc.m {
+		entries = append(entries, snapshotEntry[K, V]{Key: k, Value: item.V, UpdatedAt: item.UpdatedAt})
+	}
+	mc.mu.RUnlock()
+	return mc.codecOrDefault().Encode(w, entries)
+}
+
+// Restore builds a new MapCache from a snapshot written by Snapshot, using
+// opts the same way New does. Entries whose age already exceeds the
+// restored cache's TTL are dropped rather than resurrected, and entries
+// beyond a WithSize cap are evicted according to the configured policy as
+// they're loaded.
+func Restore[K comparable, V any](r io.Reader, opts ...OptFunc) (*MapCache[K, V], error) {
+	mc, err := New[K, V](opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []snapshotEntry[K, V]
+	if err := mc.codecOrDefault().Decode(r, &entries); err != nil {
+		return nil, err
+	}
+
+	mc.loadEntries(entries)
+	return mc, nil
+}
+
+// loadEntries inserts entries into mc exactly as Restore does for a single
+// MapCache: entries whose age already exceeds mc.TTL are dropped, and
+// entries beyond a WithSize cap are evicted according to the configured
+// policy as they're loaded. Shared with Sharded's RestoreSharded, which
+// splits a snapshot's entries across shards before calling this per shard.
+func (mc *MapCache[K, V]) loadEntries(entries []snapshotEntry[K, V]) {
+	now := time.Now()
+	var evicted []evictedEntry[K, V]
+	var inserted []snapshotEntry[K, V]
+	mc.mu.Lock()
+	for _, e := range entries {
+		if mc.TTL > 0 && now.Sub(e.UpdatedAt) >= mc.TTL {
+			continue
+		}
+		if mc.size > 0 {
+			if ev := mc.evictIfFullLocked(); ev != nil {
+				evicted = append(evicted, *ev)
+			}
+			mc.trackInsertLocked(e.Key)
+		}
+		mc.m[e.Key] = Item[V]{V: e.Value, UpdatedAt: e.UpdatedAt}
+		mc.pushExpiry(e.Key, e.UpdatedAt)
+		mc.metrics.insertions.Add(1)
+		inserted = append(inserted, e)
+	}
+	mc.mu.Unlock()
+
+	for _, ev := range evicted {
+		mc.fireEvict(ev.key, ev.val, EvictReasonCapacity)
+	}
+	for _, e := range inserted {
+		mc.fireInsert(e.Key, e.Value)
+	}
+}
+
+func (mc *MapCache[K, V]) codecOrDefault() Codec {
+	if mc.codec != nil {
+		return mc.codec
+	}
+	return GobCodec{}
+}
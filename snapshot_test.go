@@ -0,0 +1,92 @@
+package mapcache_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	mapcache "github.com/omniaura/mapcache"
+)
+
+func TestMapCache_SnapshotRestore(t *testing.T) {
+	mc, err := mapcache.New[string, int]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]int{"a": 1, "b": 2} {
+		if _, err := mc.Get(k, value(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := mc.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := mapcache.Restore[string, int](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]int{}
+	for k, item := range restored.All() {
+		got[k] = item.V
+	}
+	if got["a"] != 1 || got["b"] != 2 || len(got) != 2 {
+		t.Errorf("expected {a:1 b:2}, got %v", got)
+	}
+}
+
+func TestMapCache_RestoreDropsExpired(t *testing.T) {
+	mc, err := mapcache.New[string, int](mapcache.WithTTL(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("stale", value(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := mc.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restoring with a much shorter TTL should treat the snapshot's entry as
+	// already expired.
+	restored, err := mapcache.Restore[string, int](&buf, mapcache.WithTTL(time.Nanosecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+	for range restored.All() {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("expected stale entry to be dropped, got %d entries", count)
+	}
+}
+
+func TestMapCache_SnapshotJSONCodec(t *testing.T) {
+	mc, err := mapcache.New[string, int](mapcache.WithCodec(mapcache.JSONCodec{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.Get("a", value(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := mc.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := mapcache.Restore[string, int](&buf, mapcache.WithCodec(mapcache.JSONCodec{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := restored.Get("a", value(-1)); v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+}